@@ -0,0 +1,102 @@
+package quantum
+
+import (
+	"fmt"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// weightedEigenstate pairs an eigenstate with its sampling weight (1 for an
+// unweighted superposition) so Equal/Diff can tell apart two superpositions
+// that share the same values but were built with different amplitudes, e.g.
+// AnyWeighted([]interface{}{1, 2}, []float64{0.9, 0.1}) vs ...{0.1, 0.9}.
+type weightedEigenstate struct {
+	Value  interface{}
+	Weight float64
+}
+
+// Equal reports whether s and other have the same type (Disjunctive vs
+// Conjunctive) and hold the same eigenstates with the same weights, ignoring
+// eigenstate order (so Any(1, 2, 3) and Any(3, 2, 1) are Equal even though a
+// slice-== wouldn't agree). It deliberately takes no cmp.Option so its
+// signature matches what cmp.Equal looks for automatically on struct fields
+// of type *Superposition; use Diff if you need to customize the comparison.
+func (s *Superposition) Equal(other *Superposition) bool {
+	if s == nil || other == nil {
+		return s == other
+	}
+	if s.typ != other.typ {
+		return false
+	}
+	return multisetEqual(pairedEigenstates(s), pairedEigenstates(other), nil)
+}
+
+// Diff reports how s and other differ, ignoring eigenstate order the same
+// way Equal does, and returns "" if they're Equal. opts are forwarded to the
+// per-eigenstate comparison, so callers can pass cmpopts.EquateApprox,
+// cmpopts.IgnoreFields, etc. to tailor how individual eigenstates (or their
+// weights) are matched up.
+func (s *Superposition) Diff(other *Superposition, opts ...cmp.Option) string {
+	if s.typ != other.typ {
+		return fmt.Sprintf("-%s(...)\n+%s(...)", s.typ, other.typ)
+	}
+	sEig := pairedEigenstates(s)
+	oEig := pairedEigenstates(other)
+	missingFromOther := multisetDiff(sEig, oEig, opts)
+	missingFromSelf := multisetDiff(oEig, sEig, opts)
+	if len(missingFromOther) == 0 && len(missingFromSelf) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%s(...):\n-%v\n+%v", s.typ, missingFromOther, missingFromSelf)
+}
+
+// pairedEigenstates returns s's eigenstates paired with their weights (1 for
+// an unweighted superposition), in declaration order. Equal/Diff match them
+// up as multisets (via multisetEqual/multisetDiff) rather than relying on a
+// sort, since eigenstates of different types can render identically (e.g.
+// int(5) and float64(5) both format as "5") and a string-keyed sort would
+// wrongly treat them as interchangeable.
+func pairedEigenstates(s *Superposition) []weightedEigenstate {
+	out := make([]weightedEigenstate, len(s.values))
+	for i, v := range s.values {
+		w := 1.0
+		if len(s.weights) == len(s.values) {
+			w = s.weights[i]
+		}
+		out[i] = weightedEigenstate{Value: v, Weight: w}
+	}
+	return out
+}
+
+// multisetEqual reports whether a and b contain the same weightedEigenstates,
+// ignoring order, comparing each pair with cmp.Equal(opts...) so that values
+// of different concrete types (e.g. int(5) vs float64(5)) are never
+// considered interchangeable just because they format the same.
+func multisetEqual(a, b []weightedEigenstate, opts []cmp.Option) bool {
+	return len(a) == len(b) && len(multisetDiff(a, b, opts)) == 0
+}
+
+// multisetDiff returns the elements of a that have no remaining match in b,
+// matching greedily and consuming each b element at most once so duplicate
+// values are counted correctly.
+func multisetDiff(a, b []weightedEigenstate, opts []cmp.Option) []weightedEigenstate {
+	used := make([]bool, len(b))
+	var missing []weightedEigenstate
+	for _, av := range a {
+		found := false
+		for j, bv := range b {
+			if used[j] {
+				continue
+			}
+			if cmp.Equal(av, bv, opts...) {
+				used[j] = true
+				found = true
+				break
+			}
+		}
+		if !found {
+			missing = append(missing, av)
+		}
+	}
+	return missing
+}
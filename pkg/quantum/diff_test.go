@@ -0,0 +1,54 @@
+package quantum
+
+import "testing"
+
+func TestSuperpositionEqualIgnoresOrder(t *testing.T) {
+	a := Any(1, 2, 3)
+	b := Any(3, 2, 1)
+	if !a.Equal(b) {
+		t.Errorf("Expected Any(1,2,3) to equal Any(3,2,1)")
+	}
+}
+
+func TestSuperpositionDiff(t *testing.T) {
+	a := Any(1, 2, 3)
+	b := Any(1, 2, 4)
+	if d := a.Diff(b); d == "" {
+		t.Errorf("Expected a non-empty diff for mismatched eigenstates")
+	}
+	if d := a.Diff(Any(3, 2, 1)); d != "" {
+		t.Errorf("Expected no diff for reordered eigenstates, got %q", d)
+	}
+}
+
+func TestSuperpositionEqualDistinguishesWeights(t *testing.T) {
+	a := AnyWeighted([]interface{}{1, 2}, []float64{0.9, 0.1})
+	b := AnyWeighted([]interface{}{1, 2}, []float64{0.1, 0.9})
+	if a.Equal(b) {
+		t.Errorf("Expected superpositions with different weights not to be Equal")
+	}
+	if d := a.Diff(b); d == "" {
+		t.Errorf("Expected a non-empty diff for differing weights")
+	}
+
+	c := AnyWeighted([]interface{}{1, 2}, []float64{0.9, 0.1})
+	if !a.Equal(c) {
+		t.Errorf("Expected superpositions with identical values and weights to be Equal")
+	}
+}
+
+func TestSuperpositionEqualDistinguishesTypesThatFormatTheSame(t *testing.T) {
+	a := Any(5, 5.0)
+	b := Any(5.0, 5)
+	if !a.Equal(b) {
+		t.Errorf("Expected Any(5, 5.0) to equal Any(5.0, 5) regardless of order")
+	}
+
+	c := Any(5, 5)
+	if a.Equal(c) {
+		t.Errorf("Expected Any(5, 5.0) not to equal Any(5, 5): int(5) and float64(5.0) are distinct eigenstates even though they format the same")
+	}
+	if d := a.Diff(c); d == "" {
+		t.Errorf("Expected a non-empty diff between Any(5, 5.0) and Any(5, 5)")
+	}
+}
@@ -0,0 +1,82 @@
+package quantum
+
+import "fmt"
+
+// TypedSuperposition is a generically typed counterpart to Superposition. It
+// preserves the eigenstate type T instead of erasing it to interface{},
+// which lets Map/Filter/Reduce operate without type assertions.
+type TypedSuperposition[T any] struct {
+	values []T
+	typ    SuperpositionType
+}
+
+// TypedAny creates a disjunctive superposition of the provided values, preserving their type.
+func TypedAny[T any](values ...T) *TypedSuperposition[T] {
+	return &TypedSuperposition[T]{
+		values: values,
+		typ:    Disjunctive,
+	}
+}
+
+// TypedAll creates a conjunctive superposition of the provided values, preserving their type.
+func TypedAll[T any](values ...T) *TypedSuperposition[T] {
+	return &TypedSuperposition[T]{
+		values: values,
+		typ:    Conjunctive,
+	}
+}
+
+// Eigenstates returns the eigenstates (possible values) of the superposition.
+func (s *TypedSuperposition[T]) Eigenstates() []T {
+	return s.values
+}
+
+// Type returns whether the superposition is Disjunctive or Conjunctive.
+func (s *TypedSuperposition[T]) Type() SuperpositionType {
+	return s.typ
+}
+
+// IsTrue evaluates the truth value of the superposition based on its values.
+func (s *TypedSuperposition[T]) IsTrue() bool {
+	return len(s.values) > 0
+}
+
+// String returns a string representation of the superposition.
+func (s *TypedSuperposition[T]) String() string {
+	if len(s.values) == 1 {
+		return fmt.Sprintf("%v", s.values[0])
+	}
+	return fmt.Sprintf("%s(%v)", s.typ.String(), s.values)
+}
+
+// Map applies fn to every eigenstate, returning a new superposition of the
+// mapped type that preserves the original's Disjunctive/Conjunctive typing.
+func Map[T, U any](s *TypedSuperposition[T], fn func(T) U) *TypedSuperposition[U] {
+	mapped := make([]U, len(s.values))
+	for i, v := range s.values {
+		mapped[i] = fn(v)
+	}
+	return &TypedSuperposition[U]{values: mapped, typ: s.typ}
+}
+
+// Filter keeps only the eigenstates for which fn returns true, preserving
+// the original's Disjunctive/Conjunctive typing.
+func Filter[T any](s *TypedSuperposition[T], fn func(T) bool) *TypedSuperposition[T] {
+	filtered := make([]T, 0, len(s.values))
+	for _, v := range s.values {
+		if fn(v) {
+			filtered = append(filtered, v)
+		}
+	}
+	return &TypedSuperposition[T]{values: filtered, typ: s.typ}
+}
+
+// Reduce folds the eigenstates into a single accumulated value, starting
+// from init and applying fn left to right.
+func Reduce[T, A any](s *TypedSuperposition[T], init A, fn func(acc A, v T) A) A {
+	acc := init
+	for _, v := range s.values {
+		acc = fn(acc, v)
+	}
+	return acc
+}
@@ -0,0 +1,20 @@
+package quantum
+
+import "testing"
+
+func TestTypedAny(t *testing.T) {
+	sp := TypedAny(1, 2, 3)
+	if len(sp.Eigenstates()) != 3 {
+		t.Errorf("Expected 3 eigenstates, got %d", len(sp.Eigenstates()))
+	}
+}
+
+func TestMapFilterReduce(t *testing.T) {
+	sp := TypedAny(1, 2, 3, 4)
+	doubled := Map(sp, func(v int) int { return v * 2 })
+	even := Filter(doubled, func(v int) bool { return v%4 == 0 })
+	sum := Reduce(even, 0, func(acc, v int) int { return acc + v })
+	if sum != 12 {
+		t.Errorf("Expected sum 12, got %d", sum)
+	}
+}
@@ -0,0 +1,282 @@
+package quantum
+
+import "fmt"
+
+// iterator yields a superposition's eigenstates one at a time, so a
+// LazySuperposition never has to materialize a full Cartesian product up
+// front.
+type iterator interface {
+	Next() (interface{}, bool)
+}
+
+// sliceIterator adapts an already-known slice of values to the iterator interface.
+type sliceIterator struct {
+	values []interface{}
+	pos    int
+}
+
+func (it *sliceIterator) Next() (interface{}, bool) {
+	if it.pos >= len(it.values) {
+		return nil, false
+	}
+	v := it.values[it.pos]
+	it.pos++
+	return v, true
+}
+
+// productIterator lazily yields op(left, right) for every pair in the
+// Cartesian product of a left iterator and a buffered slice of right-hand
+// values, without ever allocating the full |left|*|right| result set.
+type productIterator struct {
+	left  iterator
+	right []interface{}
+	op    func(a, b interface{}) (interface{}, error)
+
+	curLeft  interface{}
+	haveLeft bool
+	rightPos int
+}
+
+func (p *productIterator) Next() (interface{}, bool) {
+	for {
+		if !p.haveLeft {
+			v, ok := p.left.Next()
+			if !ok {
+				return nil, false
+			}
+			p.curLeft, p.haveLeft, p.rightPos = v, true, 0
+		}
+		for p.rightPos < len(p.right) {
+			rv := p.right[p.rightPos]
+			p.rightPos++
+			res, err := p.op(p.curLeft, rv)
+			if err != nil {
+				continue
+			}
+			return res, true
+		}
+		p.haveLeft = false
+	}
+}
+
+// LazySuperposition is a streaming counterpart to Superposition: it wraps an
+// iterator plus its declared type and only materializes eigenstates as they
+// are pulled, instead of eagerly computing the whole Cartesian product.
+// Already-pulled eigenstates are buffered so Eigenstates/String/IsTrue can be
+// called more than once without re-driving the iterator.
+type LazySuperposition struct {
+	it       iterator
+	typ      SuperpositionType
+	buffered []interface{}
+	done     bool
+}
+
+// NewLazy wraps it as a LazySuperposition of the given type.
+func NewLazy(it iterator, typ SuperpositionType) *LazySuperposition {
+	return &LazySuperposition{it: it, typ: typ}
+}
+
+// toLazyOperand adapts a scalar, *Superposition, or *LazySuperposition into
+// an iterator plus its type, without forcing a *LazySuperposition to replay
+// what it has already produced.
+//
+// A weighted *Superposition operand has its weights dropped here: iterator
+// only yields values, with no channel for a per-eigenstate weight to travel
+// alongside them. Lazy arithmetic (LazyAdd and friends) is therefore always
+// unweighted, unlike its eager Add/Subtract/... counterparts.
+func toLazyOperand(val interface{}) (iterator, SuperpositionType) {
+	if ls, ok := val.(*LazySuperposition); ok {
+		return &resumableIterator{ls: ls}, ls.typ
+	}
+	values, typ := extractValues(val)
+	return &sliceIterator{values: values}, typ
+}
+
+// resumableIterator lets a LazySuperposition serve as the left-hand iterator
+// of a productIterator: it replays what's already buffered before pulling
+// further eigenstates from the underlying LazySuperposition.
+type resumableIterator struct {
+	ls  *LazySuperposition
+	pos int
+}
+
+func (r *resumableIterator) Next() (interface{}, bool) {
+	if r.pos < len(r.ls.buffered) {
+		v := r.ls.buffered[r.pos]
+		r.pos++
+		return v, true
+	}
+	v, ok := r.ls.next()
+	if ok {
+		r.pos++
+	}
+	return v, ok
+}
+
+// combineLazy lazily composes a and b with op, buffering only b's values
+// (the right-hand side of the Cartesian product) rather than the full
+// product that combineValues would allocate. Like toLazyOperand, it ignores
+// any weights a or b carry; see toLazyOperand's doc comment.
+func combineLazy(a, b interface{}, op func(a, b interface{}) (interface{}, error)) *LazySuperposition {
+	aIt, aTyp := toLazyOperand(a)
+	bValues, bTyp := extractValues(b)
+
+	typ := Disjunctive
+	if aTyp == Conjunctive || bTyp == Conjunctive {
+		typ = Conjunctive
+	}
+
+	return NewLazy(&productIterator{left: aIt, right: bValues, op: op}, typ)
+}
+
+// LazyAdd is the lazy counterpart to Add: it composes iterators instead of
+// materializing the full Cartesian product, so chains like
+// LazyAdd(LazyAdd(a, b), c) don't blow up memory on large operands.
+func LazyAdd(a, b interface{}) *LazySuperposition { return combineLazy(a, b, addValues) }
+
+// LazySubtract is the lazy counterpart to Subtract.
+func LazySubtract(a, b interface{}) *LazySuperposition { return combineLazy(a, b, subValues) }
+
+// LazyMultiply is the lazy counterpart to Multiply.
+func LazyMultiply(a, b interface{}) *LazySuperposition { return combineLazy(a, b, mulValues) }
+
+// LazyDivide is the lazy counterpart to Divide.
+func LazyDivide(a, b interface{}) *LazySuperposition { return combineLazy(a, b, divValues) }
+
+// LazyModulo is the lazy counterpart to Modulo.
+func LazyModulo(a, b interface{}) *LazySuperposition { return combineLazy(a, b, modValues) }
+
+// next pulls and buffers the iterator's next eigenstate.
+func (s *LazySuperposition) next() (interface{}, bool) {
+	if s.done {
+		return nil, false
+	}
+	v, ok := s.it.Next()
+	if !ok {
+		s.done = true
+		return nil, false
+	}
+	s.buffered = append(s.buffered, v)
+	return v, true
+}
+
+// IsTrue evaluates the truth value of the superposition. A Disjunctive
+// superposition short-circuits on the first produced eigenstate rather than
+// draining the iterator; a Conjunctive one is drained first and is true if
+// it produced any eigenstate at all.
+func (s *LazySuperposition) IsTrue() bool {
+	if len(s.buffered) > 0 {
+		return true
+	}
+	if s.done {
+		return false
+	}
+	if s.typ == Disjunctive {
+		_, ok := s.next()
+		return ok
+	}
+	for {
+		if _, ok := s.next(); !ok {
+			break
+		}
+	}
+	return len(s.buffered) > 0
+}
+
+// Eigenstates drains the iterator and returns every eigenstate it produced.
+func (s *LazySuperposition) Eigenstates() []interface{} {
+	for {
+		if _, ok := s.next(); !ok {
+			break
+		}
+	}
+	return s.buffered
+}
+
+// Type returns whether the superposition is Disjunctive or Conjunctive.
+func (s *LazySuperposition) Type() SuperpositionType {
+	return s.typ
+}
+
+// String drains the iterator and returns a string representation of the superposition.
+func (s *LazySuperposition) String() string {
+	values := s.Eigenstates()
+	if len(values) == 1 {
+		return fmt.Sprintf("%v", values[0])
+	}
+	return fmt.Sprintf("%s(%v)", s.typ.String(), values)
+}
+
+// Take pulls and returns up to the first n eigenstates, buffering them for
+// any later call. It's the bounded alternative to Eigenstates for
+// superpositions too large (or infinite) to fully drain.
+func (s *LazySuperposition) Take(n int) []interface{} {
+	for len(s.buffered) < n {
+		if _, ok := s.next(); !ok {
+			break
+		}
+	}
+	if n > len(s.buffered) {
+		n = len(s.buffered)
+	}
+	out := make([]interface{}, n)
+	copy(out, s.buffered[:n])
+	return out
+}
+
+// Limit returns a new LazySuperposition that yields at most n eigenstates of s.
+func (s *LazySuperposition) Limit(n int) *LazySuperposition {
+	return NewLazy(&limitIterator{src: &resumableIterator{ls: s}, n: n}, s.typ)
+}
+
+// limitIterator caps another LazySuperposition's output at n eigenstates.
+type limitIterator struct {
+	src  iterator
+	n    int
+	seen int
+}
+
+func (l *limitIterator) Next() (interface{}, bool) {
+	if l.seen >= l.n {
+		return nil, false
+	}
+	v, ok := l.src.Next()
+	if !ok {
+		return nil, false
+	}
+	l.seen++
+	return v, true
+}
+
+// Stream drains the iterator on a background goroutine and returns a channel
+// of its eigenstates, closed once the superposition is exhausted. Eigenstates
+// already buffered by an earlier call are sent first. Every eigenstate Stream
+// pulls is also buffered on s, as with Take and Eigenstates, so memory use
+// grows with the number of eigenstates streamed.
+//
+// Pass a done channel to stop the producer goroutine early (e.g. when a
+// caller ranging over the result stops partway through) — closing done
+// unblocks a pending send and the goroutine returns instead of leaking.
+func (s *LazySuperposition) Stream(done ...<-chan struct{}) <-chan interface{} {
+	var stop <-chan struct{}
+	if len(done) > 0 {
+		stop = done[0]
+	}
+	ch := make(chan interface{})
+	it := &resumableIterator{ls: s}
+	go func() {
+		defer close(ch)
+		for {
+			v, ok := it.Next()
+			if !ok {
+				return
+			}
+			select {
+			case ch <- v:
+			case <-stop:
+				return
+			}
+		}
+	}()
+	return ch
+}
@@ -0,0 +1,115 @@
+package quantum
+
+import (
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestLazyAdd(t *testing.T) {
+	sp := LazyAdd(Any(1, 2, 3), 10)
+	got := sp.Eigenstates()
+	want := []float64{11, 12, 13}
+	if len(got) != len(want) {
+		t.Fatalf("Expected %d eigenstates, got %d", len(want), len(got))
+	}
+	for i, w := range want {
+		if got[i] != w {
+			t.Errorf("Eigenstate %d: expected %v, got %v", i, w, got[i])
+		}
+	}
+}
+
+func TestLazyAddIgnoresWeights(t *testing.T) {
+	// Unlike Add, LazyAdd has no per-eigenstate weight to propagate (see
+	// combineLazy's doc comment), so a heavily skewed weighting doesn't
+	// change which eigenstates come out, only an eager Collapse/RandomValue
+	// call on the *Superposition operand would have seen the skew.
+	a := AnyWeighted([]interface{}{1, 2}, []float64{1000, 1})
+	got := LazyAdd(a, 10).Eigenstates()
+	want := []float64{11, 12}
+	if len(got) != len(want) {
+		t.Fatalf("Expected %d eigenstates, got %d", len(want), len(got))
+	}
+	for i, w := range want {
+		if got[i] != w {
+			t.Errorf("Eigenstate %d: expected %v, got %v", i, w, got[i])
+		}
+	}
+}
+
+func TestLazyTakeAndLimit(t *testing.T) {
+	sp := LazyMultiply(Any(1, 2, 3, 4, 5), 2)
+	if got := sp.Take(2); len(got) != 2 {
+		t.Fatalf("Expected 2 eigenstates from Take(2), got %d", len(got))
+	}
+
+	limited := LazyMultiply(Any(1, 2, 3, 4, 5), 2).Limit(3)
+	if got := limited.Eigenstates(); len(got) != 3 {
+		t.Fatalf("Expected 3 eigenstates from Limit(3), got %d", len(got))
+	}
+}
+
+func TestLazyIsTrueShortCircuits(t *testing.T) {
+	sp := LazyAdd(Any(1, 2, 3), 1)
+	if !sp.IsTrue() {
+		t.Errorf("Expected IsTrue() to be true")
+	}
+	if len(sp.buffered) != 1 {
+		t.Errorf("Expected IsTrue() to short-circuit after 1 eigenstate, buffered %d", len(sp.buffered))
+	}
+}
+
+func TestStreamStopsOnDone(t *testing.T) {
+	runtime.Gosched()
+	before := runtime.NumGoroutine()
+
+	values := make([]interface{}, 10000)
+	for i := range values {
+		values[i] = i
+	}
+	sp := NewLazy(&sliceIterator{values: values}, Disjunctive)
+
+	done := make(chan struct{})
+	ch := sp.Stream(done)
+	<-ch // consume a single eigenstate, leaving the producer blocked mid-stream
+	close(done)
+
+	deadline := time.Now().Add(time.Second)
+	for runtime.NumGoroutine() > before {
+		if time.Now().After(deadline) {
+			t.Fatalf("Stream's producer goroutine did not exit after done was closed")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func chainedEagerAdd(n int) *Superposition {
+	vals := make([]interface{}, n)
+	for i := 0; i < n; i++ {
+		vals[i] = i
+	}
+	sp := Any(vals...)
+	return Add(Add(sp, sp), 1)
+}
+
+func chainedLazyAdd(n int) *LazySuperposition {
+	vals := make([]interface{}, n)
+	for i := 0; i < n; i++ {
+		vals[i] = i
+	}
+	sp := Any(vals...)
+	return LazyAdd(LazyAdd(sp, sp), 1)
+}
+
+func BenchmarkChainedArithmeticEager(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		chainedEagerAdd(200).Eigenstates()
+	}
+}
+
+func BenchmarkChainedArithmeticLazyTake(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		chainedLazyAdd(200).Take(10)
+	}
+}
@@ -0,0 +1,308 @@
+package quantum
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"reflect"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+var (
+	errDivByZero     = errors.New("division by zero")
+	errModByZero     = errors.New("modulo by zero")
+	errUnsupportedOp = errors.New("unsupported operation")
+)
+
+// Coercer converts an arbitrary value into a float64 for arithmetic,
+// returning ok=false if the value can't be coerced. It lets a Namespace
+// support numeric-like types beyond the built-in int/uint/float kinds.
+type Coercer func(v interface{}) (f float64, ok bool)
+
+// Option configures a Namespace returned by New.
+type Option func(*Namespace)
+
+// WithPrecision rounds arithmetic results to p decimal places. p <= 0 disables rounding.
+func WithPrecision(p int) Option {
+	return func(n *Namespace) { n.precision = p }
+}
+
+// WithTolerance sets the epsilon used when comparing floating point values for equality.
+func WithTolerance(t float64) Option {
+	return func(n *Namespace) { n.tolerance = t }
+}
+
+// WithCoercer overrides how operands are converted to float64, in place of the default getNumericValue.
+func WithCoercer(c Coercer) Option {
+	return func(n *Namespace) { n.coercer = c }
+}
+
+// WithRand sets the random source used by namespace-scoped operations such as RandomValue.
+func WithRand(r *rand.Rand) Option {
+	return func(n *Namespace) { n.rng = r }
+}
+
+// Namespace bundles the configuration (numeric precision, comparison
+// tolerance, custom coercion, RNG source) that the package-level arithmetic
+// functions previously left as hidden global state, such as the rand.Seed
+// call in RandomValue. A Namespace is safe for embedding in template
+// engines that need several independently configured instances.
+type Namespace struct {
+	precision int
+	tolerance float64
+	coercer   Coercer
+	rng       *rand.Rand
+}
+
+// New creates a Namespace with the given options applied over sane defaults.
+func New(opts ...Option) *Namespace {
+	n := &Namespace{
+		rng: rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+	for _, opt := range opts {
+		opt(n)
+	}
+	return n
+}
+
+// coerce converts v to a float64 using the namespace's Coercer if set, falling back to getNumericValue.
+func (n *Namespace) coerce(v interface{}) (float64, bool) {
+	if n.coercer != nil {
+		return n.coercer(v)
+	}
+	f, kind := getNumericValue(v)
+	return f, kind != reflect.Invalid
+}
+
+// round applies the namespace's configured precision to f.
+func (n *Namespace) round(f float64) float64 {
+	if n.precision <= 0 {
+		return f
+	}
+	mult := math.Pow(10, float64(n.precision))
+	return math.Round(f*mult) / mult
+}
+
+// evalArithmetic applies op to two numeric operands. It centralizes the
+// switch that performArithmetic and DoArithmetic both dispatch through.
+func evalArithmetic(a, b float64, op rune) (float64, error) {
+	switch op {
+	case '+':
+		return a + b, nil
+	case '-':
+		return a - b, nil
+	case '*':
+		return a * b, nil
+	case '/':
+		if b == 0 {
+			return 0, errDivByZero
+		}
+		return a / b, nil
+	case '%':
+		if b == 0 {
+			return 0, errModByZero
+		}
+		return math.Mod(a, b), nil
+	case '^':
+		return math.Pow(a, b), nil
+	default:
+		return 0, errUnsupportedOp
+	}
+}
+
+// DoArithmetic applies op (one of '+', '-', '*', '/', '%', '^') across the
+// Cartesian product of a and b's eigenstates, in the style of Hugo's
+// tpl/math dispatcher, and preserves Disjunctive/Conjunctive typing. If
+// either operand is weighted, the result's weights are the pairwise products
+// of the operands' weights, the same convention combineValues uses for the
+// package-level Add/Subtract/Multiply/Divide/Modulo.
+func (n *Namespace) DoArithmetic(a, b interface{}, op rune) *Superposition {
+	aValues, aWeights, aTyp := extractWeighted(a)
+	bValues, bWeights, bTyp := extractWeighted(b)
+
+	resultValues := []interface{}{}
+	var resultWeights []float64
+	hasWeights := aWeights != nil || bWeights != nil
+
+	for i, av := range aValues {
+		af, ok := n.coerce(av)
+		if !ok {
+			continue
+		}
+		for j, bv := range bValues {
+			bf, ok := n.coerce(bv)
+			if !ok {
+				continue
+			}
+			res, err := evalArithmetic(af, bf, op)
+			if err != nil {
+				continue
+			}
+			resultValues = append(resultValues, n.round(res))
+			if hasWeights {
+				resultWeights = append(resultWeights, weightAt(aWeights, i)*weightAt(bWeights, j))
+			}
+		}
+	}
+
+	resultTyp := Disjunctive
+	if aTyp == Conjunctive || bTyp == Conjunctive {
+		resultTyp = Conjunctive
+	}
+	return &Superposition{values: resultValues, weights: resultWeights, typ: resultTyp}
+}
+
+// liftUnary applies fn to every eigenstate of s, preserving its typing and
+// carrying its weights (if any) over unchanged, since fn maps one eigenstate
+// to one result rather than combining eigenstates from two operands.
+func (n *Namespace) liftUnary(s interface{}, fn func(float64) float64) *Superposition {
+	values, weights, typ := extractWeighted(s)
+	result := []interface{}{}
+	var resultWeights []float64
+	for i, v := range values {
+		f, ok := n.coerce(v)
+		if !ok {
+			continue
+		}
+		result = append(result, n.round(fn(f)))
+		if weights != nil {
+			resultWeights = append(resultWeights, weights[i])
+		}
+	}
+	return &Superposition{values: result, weights: resultWeights, typ: typ}
+}
+
+// Ceil rounds every eigenstate of s up to the nearest integer.
+func (n *Namespace) Ceil(s interface{}) *Superposition { return n.liftUnary(s, math.Ceil) }
+
+// Floor rounds every eigenstate of s down to the nearest integer.
+func (n *Namespace) Floor(s interface{}) *Superposition { return n.liftUnary(s, math.Floor) }
+
+// Round rounds every eigenstate of s to the nearest integer.
+func (n *Namespace) Round(s interface{}) *Superposition { return n.liftUnary(s, math.Round) }
+
+// Log returns the natural logarithm of every eigenstate of s.
+func (n *Namespace) Log(s interface{}) *Superposition { return n.liftUnary(s, math.Log) }
+
+// Sqrt returns the square root of every eigenstate of s.
+func (n *Namespace) Sqrt(s interface{}) *Superposition { return n.liftUnary(s, math.Sqrt) }
+
+// Pow raises base to exp across their Cartesian product.
+func (n *Namespace) Pow(base, exp interface{}) *Superposition {
+	return n.DoArithmetic(base, exp, '^')
+}
+
+// Min returns the smallest eigenstate of s and whether s had any eigenstates
+// to compare. s's weights, if any, are ignored: a single scalar result has
+// no eigenstate to carry a weight forward onto.
+func (n *Namespace) Min(s interface{}) (float64, bool) {
+	values, _ := extractValues(s)
+	min, found := 0.0, false
+	for _, v := range values {
+		f, ok := n.coerce(v)
+		if !ok {
+			continue
+		}
+		if !found || f < min {
+			min, found = f, true
+		}
+	}
+	return min, found
+}
+
+// Max returns the largest eigenstate of s and whether s had any eigenstates
+// to compare. s's weights, if any, are ignored: a single scalar result has
+// no eigenstate to carry a weight forward onto.
+func (n *Namespace) Max(s interface{}) (float64, bool) {
+	values, _ := extractValues(s)
+	max, found := 0.0, false
+	for _, v := range values {
+		f, ok := n.coerce(v)
+		if !ok {
+			continue
+		}
+		if !found || f > max {
+			max, found = f, true
+		}
+	}
+	return max, found
+}
+
+// Sum adds together every eigenstate of s. s's weights, if any, are ignored:
+// a single scalar result has no eigenstate to carry a weight forward onto.
+func (n *Namespace) Sum(s interface{}) float64 {
+	values, _ := extractValues(s)
+	sum := 0.0
+	for _, v := range values {
+		if f, ok := n.coerce(v); ok {
+			sum += f
+		}
+	}
+	return n.round(sum)
+}
+
+// Product multiplies together every eigenstate of s. s's weights, if any,
+// are ignored: a single scalar result has no eigenstate to carry a weight
+// forward onto.
+func (n *Namespace) Product(s interface{}) float64 {
+	values, _ := extractValues(s)
+	product := 1.0
+	for _, v := range values {
+		if f, ok := n.coerce(v); ok {
+			product *= f
+		}
+	}
+	return n.round(product)
+}
+
+// RandomValue returns a random eigenstate from s, sampled (honoring s's
+// weights, if any) through the namespace's configured RNG instead of the
+// package-level default source (*Superposition).RandomValue falls back to.
+func (n *Namespace) RandomValue(s *Superposition) interface{} {
+	return s.RandomValue(n.rng)
+}
+
+// EqualTo compares a and b like the package-level EqualTo, except numeric
+// operands are considered equal when they're within the namespace's
+// configured tolerance rather than requiring an exact float64 match; any
+// other type still falls back to cmp.Equal.
+func (n *Namespace) EqualTo(a, b interface{}, opts ...cmp.Option) *Superposition {
+	comp := func(x, y interface{}) (bool, error) {
+		xf, xok := n.coerce(x)
+		yf, yok := n.coerce(y)
+		if xok && yok {
+			return math.Abs(xf-yf) <= n.tolerance, nil
+		}
+		return cmp.Equal(x, y, opts...), nil
+	}
+	resultValues, resultTyp := compareValues(a, b, comp)
+	return &Superposition{values: resultValues, typ: resultTyp}
+}
+
+// LessThan compares a and b using the namespace's Coercer instead of the
+// package-level getNumericValue.
+func (n *Namespace) LessThan(a, b interface{}) *Superposition {
+	return n.compare(a, b, func(x, y float64) bool { return x < y })
+}
+
+// GreaterThan compares a and b using the namespace's Coercer instead of the
+// package-level getNumericValue.
+func (n *Namespace) GreaterThan(a, b interface{}) *Superposition {
+	return n.compare(a, b, func(x, y float64) bool { return x > y })
+}
+
+// compare is the shared implementation behind Namespace.LessThan/GreaterThan.
+func (n *Namespace) compare(a, b interface{}, cond func(x, y float64) bool) *Superposition {
+	comp := func(x, y interface{}) (bool, error) {
+		xf, xok := n.coerce(x)
+		yf, yok := n.coerce(y)
+		if !xok || !yok {
+			return false, errUnsupportedOp
+		}
+		return cond(xf, yf), nil
+	}
+	resultValues, resultTyp := compareValues(a, b, comp)
+	return &Superposition{values: resultValues, typ: resultTyp}
+}
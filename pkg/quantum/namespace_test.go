@@ -0,0 +1,70 @@
+package quantum
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestNamespaceDoArithmetic(t *testing.T) {
+	ns := New(WithPrecision(2))
+	sp := ns.DoArithmetic(10, 3, '/')
+	if len(sp.Eigenstates()) != 1 {
+		t.Fatalf("Expected 1 eigenstate, got %d", len(sp.Eigenstates()))
+	}
+	if sp.Eigenstates()[0] != 3.33 {
+		t.Errorf("Expected 3.33, got %v", sp.Eigenstates()[0])
+	}
+}
+
+func TestNamespaceSumProduct(t *testing.T) {
+	ns := New()
+	sp := Any(1, 2, 3, 4)
+	if sum := ns.Sum(sp); sum != 10 {
+		t.Errorf("Expected sum 10, got %v", sum)
+	}
+	if product := ns.Product(sp); product != 24 {
+		t.Errorf("Expected product 24, got %v", product)
+	}
+}
+
+func TestNamespaceRandomValueUsesConfiguredRand(t *testing.T) {
+	ns := New(WithRand(rand.New(rand.NewSource(1))))
+	sp := AnyWeighted([]interface{}{"a", "b"}, []float64{0, 1})
+	for i := 0; i < 10; i++ {
+		if v := ns.RandomValue(sp); v != "b" {
+			t.Fatalf("Expected always \"b\", got %v", v)
+		}
+	}
+}
+
+func TestNamespaceDoArithmeticPropagatesWeights(t *testing.T) {
+	ns := New()
+	a := AnyWeighted([]interface{}{1, 2}, []float64{1, 3})
+	b := AnyWeighted([]interface{}{10, 20}, []float64{1, 1})
+	sum := ns.DoArithmetic(a, b, '+')
+	weights := sum.Weights()
+	if len(weights) != len(sum.Eigenstates()) {
+		t.Fatalf("Expected one weight per eigenstate, got %d weights for %d eigenstates", len(weights), len(sum.Eigenstates()))
+	}
+}
+
+func TestNamespaceCeilPreservesWeights(t *testing.T) {
+	ns := New()
+	sp := AnyWeighted([]interface{}{1.4, 2.6}, []float64{0.3, 0.7})
+	ceiled := ns.Ceil(sp)
+	if diff := cmp.Diff(sp.Weights(), ceiled.Weights()); diff != "" {
+		t.Errorf("Expected Ceil to carry weights over unchanged (-want +got):\n%s", diff)
+	}
+}
+
+func TestNamespaceEqualToTolerance(t *testing.T) {
+	ns := New(WithTolerance(0.01))
+	if !ns.EqualTo(1.0, 1.005).IsTrue() {
+		t.Errorf("Expected 1.0 and 1.005 to be equal within tolerance 0.01")
+	}
+	if ns.EqualTo(1.0, 1.1).IsTrue() {
+		t.Errorf("Expected 1.0 and 1.1 not to be equal within tolerance 0.01")
+	}
+}
@@ -5,10 +5,13 @@ package quantum
 import (
 	"errors"
 	"fmt"
-	"math"
 	"math/rand"
 	"reflect"
+	"sort"
+	"sync"
 	"time"
+
+	"github.com/google/go-cmp/cmp"
 )
 
 // SuperpositionType represents the type of superposition: Disjunctive (any) or Conjunctive (all).
@@ -19,10 +22,13 @@ const (
 	Conjunctive                          // Represents a conjunctive superposition (all states must be true).
 )
 
-// Superposition represents a quantum superposition of values.
+// Superposition represents a quantum superposition of values. weights is nil
+// for an unweighted superposition; when present it parallels values and
+// carries each eigenstate's (unnormalized) sampling amplitude.
 type Superposition struct {
-	values []interface{}
-	typ    SuperpositionType
+	values  []interface{}
+	weights []float64
+	typ     SuperpositionType
 }
 
 // Any creates a disjunctive superposition of the provided values.
@@ -41,48 +47,75 @@ func All(values ...interface{}) *Superposition {
 	}
 }
 
+// AnyWeighted creates a disjunctive superposition whose eigenstates are
+// sampled by RandomValue/Collapse proportionally to weights. If weights
+// doesn't have one entry per value, the superposition is left unweighted.
+func AnyWeighted(values []interface{}, weights []float64) *Superposition {
+	sp := &Superposition{values: values, typ: Disjunctive}
+	if len(weights) == len(values) {
+		sp.weights = weights
+	}
+	return sp
+}
+
+// AllWeighted creates a conjunctive superposition whose eigenstates are
+// sampled by RandomValue/Collapse proportionally to weights. If weights
+// doesn't have one entry per value, the superposition is left unweighted.
+func AllWeighted(values []interface{}, weights []float64) *Superposition {
+	sp := &Superposition{values: values, typ: Conjunctive}
+	if len(weights) == len(values) {
+		sp.weights = weights
+	}
+	return sp
+}
+
 // Add performs addition between two operands, which can be scalars or superpositions.
 func Add(a, b interface{}) *Superposition {
-	resultValues, resultTyp := combineValues(a, b, addValues)
+	resultValues, resultWeights, resultTyp := combineValues(a, b, addValues)
 	return &Superposition{
-		values: resultValues,
-		typ:    resultTyp,
+		values:  resultValues,
+		weights: resultWeights,
+		typ:     resultTyp,
 	}
 }
 
 // Subtract performs subtraction between two operands, which can be scalars or superpositions.
 func Subtract(a, b interface{}) *Superposition {
-	resultValues, resultTyp := combineValues(a, b, subValues)
+	resultValues, resultWeights, resultTyp := combineValues(a, b, subValues)
 	return &Superposition{
-		values: resultValues,
-		typ:    resultTyp,
+		values:  resultValues,
+		weights: resultWeights,
+		typ:     resultTyp,
 	}
 }
 
 // Multiply performs multiplication between two operands, which can be scalars or superpositions.
 func Multiply(a, b interface{}) *Superposition {
-	resultValues, resultTyp := combineValues(a, b, mulValues)
+	resultValues, resultWeights, resultTyp := combineValues(a, b, mulValues)
 	return &Superposition{
-		values: resultValues,
-		typ:    resultTyp,
+		values:  resultValues,
+		weights: resultWeights,
+		typ:     resultTyp,
 	}
 }
 
 // Divide performs division between two operands, which can be scalars or superpositions.
 func Divide(a, b interface{}) *Superposition {
-	resultValues, resultTyp := combineValues(a, b, divValues)
+	resultValues, resultWeights, resultTyp := combineValues(a, b, divValues)
 	return &Superposition{
-		values: resultValues,
-		typ:    resultTyp,
+		values:  resultValues,
+		weights: resultWeights,
+		typ:     resultTyp,
 	}
 }
 
 // Modulo performs modulus operation between two operands, which can be scalars or superpositions.
 func Modulo(a, b interface{}) *Superposition {
-	resultValues, resultTyp := combineValues(a, b, modValues)
+	resultValues, resultWeights, resultTyp := combineValues(a, b, modValues)
 	return &Superposition{
-		values: resultValues,
-		typ:    resultTyp,
+		values:  resultValues,
+		weights: resultWeights,
+		typ:     resultTyp,
 	}
 }
 
@@ -105,8 +138,15 @@ func GreaterThan(a, b interface{}) *Superposition {
 }
 
 // EqualTo performs a comparison between two operands and returns a superposition of values from the left operand that satisfy the comparison.
-func EqualTo(a, b interface{}) *Superposition {
-	resultValues, resultTyp := compareValues(a, b, equalToValues)
+// Numeric operands are compared as numbers; any other type falls back to
+// github.com/google/go-cmp/cmp.Equal, so opts (e.g. cmpopts.EquateApprox,
+// cmpopts.SortSlices, cmpopts.IgnoreFields) can tailor how structs, maps, and
+// slices are matched.
+func EqualTo(a, b interface{}, opts ...cmp.Option) *Superposition {
+	comp := func(x, y interface{}) (bool, error) {
+		return performComparison(x, y, '=', opts...)
+	}
+	resultValues, resultTyp := compareValues(a, b, comp)
 	return &Superposition{
 		values: resultValues,
 		typ:    resultTyp,
@@ -178,25 +218,29 @@ func greaterThanValues(a, b interface{}) (bool, error) {
 	return performComparison(a, b, '>')
 }
 
-func equalToValues(a, b interface{}) (bool, error) {
-	return performComparison(a, b, '=')
-}
-
 // combineValues combines two operands using the provided operation function.
-func combineValues(a, b interface{}, op func(a, b interface{}) (interface{}, error)) ([]interface{}, SuperpositionType) {
-	aValues, aTyp := extractValues(a)
-	bValues, bTyp := extractValues(b)
+// When either operand carries weights, the result's weights are the
+// Cartesian product of the operands' amplitudes (missing amplitudes default
+// to 1); otherwise the result is left unweighted.
+func combineValues(a, b interface{}, op func(a, b interface{}) (interface{}, error)) ([]interface{}, []float64, SuperpositionType) {
+	aValues, aWeights, aTyp := extractWeighted(a)
+	bValues, bWeights, bTyp := extractWeighted(b)
 
 	resultValues := []interface{}{}
+	var resultWeights []float64
+	hasWeights := aWeights != nil || bWeights != nil
 
 	// Perform operation on combinations of values
-	for _, av := range aValues {
-		for _, bv := range bValues {
+	for i, av := range aValues {
+		for j, bv := range bValues {
 			res, err := op(av, bv)
 			if err != nil {
 				continue
 			}
 			resultValues = append(resultValues, res)
+			if hasWeights {
+				resultWeights = append(resultWeights, weightAt(aWeights, i)*weightAt(bWeights, j))
+			}
 		}
 	}
 
@@ -206,7 +250,16 @@ func combineValues(a, b interface{}, op func(a, b interface{}) (interface{}, err
 		resultTyp = Conjunctive
 	}
 
-	return resultValues, resultTyp
+	return resultValues, resultWeights, resultTyp
+}
+
+// weightAt returns weights[i], or 1 if weights is nil (an unweighted operand
+// contributes no bias to a Cartesian product of amplitudes).
+func weightAt(weights []float64, i int) float64 {
+	if weights == nil {
+		return 1
+	}
+	return weights[i]
 }
 
 // compareValues compares two operands using the provided comparison function.
@@ -244,13 +297,21 @@ func compareValues(a, b interface{}, comp func(a, b interface{}) (bool, error))
 
 // extractValues extracts values and type from an operand, which can be a scalar or a superposition.
 func extractValues(val interface{}) ([]interface{}, SuperpositionType) {
+	values, _, typ := extractWeighted(val)
+	return values, typ
+}
+
+// extractWeighted extracts values, weights (nil if unweighted), and type from
+// an operand, which can be a scalar or a superposition.
+func extractWeighted(val interface{}) ([]interface{}, []float64, SuperpositionType) {
 	if s, ok := val.(*Superposition); ok {
-		return s.values, s.typ
+		return s.values, s.weights, s.typ
 	}
-	return []interface{}{val}, Disjunctive
+	return []interface{}{val}, nil, Disjunctive
 }
 
-// performArithmetic performs arithmetic operations between two values.
+// performArithmetic performs arithmetic operations between two values,
+// dispatching through the same evalArithmetic switch Namespace.DoArithmetic uses.
 func performArithmetic(a, b interface{}, op rune) (interface{}, error) {
 	aVal, aKind := getNumericValue(a)
 	bVal, bKind := getNumericValue(b)
@@ -259,34 +320,21 @@ func performArithmetic(a, b interface{}, op rune) (interface{}, error) {
 		return nil, errors.New("unsupported type in arithmetic operation")
 	}
 
-	switch op {
-	case '+':
-		return aVal + bVal, nil
-	case '-':
-		return aVal - bVal, nil
-	case '*':
-		return aVal * bVal, nil
-	case '/':
-		if bVal == 0 {
-			return nil, errors.New("division by zero")
-		}
-		return aVal / bVal, nil
-	case '%':
-		if bVal == 0 {
-			return nil, errors.New("modulo by zero")
-		}
-		return math.Mod(aVal, bVal), nil
-	default:
-		return nil, errors.New("unsupported operation")
-	}
+	return evalArithmetic(aVal, bVal, op)
 }
 
-// performComparison performs comparison operations between two values.
-func performComparison(a, b interface{}, op rune) (bool, error) {
+// performComparison performs comparison operations between two values. For
+// '=', if either operand isn't numeric it falls back to cmp.Equal so
+// eigenstates that are structs, maps, or slices can still be compared; opts
+// are forwarded to cmp.Equal untouched.
+func performComparison(a, b interface{}, op rune, opts ...cmp.Option) (bool, error) {
 	aVal, aKind := getNumericValue(a)
 	bVal, bKind := getNumericValue(b)
 
 	if aKind == reflect.Invalid || bKind == reflect.Invalid {
+		if op == '=' {
+			return cmp.Equal(a, b, opts...), nil
+		}
 		return false, errors.New("unsupported type in comparison")
 	}
 
@@ -317,11 +365,85 @@ func getNumericValue(val interface{}) (float64, reflect.Kind) {
 	}
 }
 
-// RandomValue returns a random eigenstate from the superposition.
-func (s *Superposition) RandomValue() interface{} {
-	rand.Seed(time.Now().UnixNano())
+// defaultRand is seeded once, rather than reseeded on every RandomValue call.
+var defaultRand = rand.New(rand.NewSource(time.Now().UnixNano()))
+
+// randMu guards every draw this package makes from an *rand.Rand, default or
+// caller-supplied, since *rand.Rand is not safe for concurrent use on its
+// own. Without it, two goroutines calling RandomValue on the same
+// superposition (or a Namespace's shared rng) would race on defaultRand's or
+// the Namespace's *rand.Rand internal state.
+var randMu sync.Mutex
+
+// RandomValue returns a random eigenstate from the superposition. If the
+// superposition was built with weights (AnyWeighted/AllWeighted, or derived
+// from one via an operator), the eigenstate is sampled proportionally to its
+// weight via cumulative-distribution binary search; otherwise sampling is
+// uniform. Pass an *rand.Rand to sample deterministically instead of using
+// the package-level default source. Safe to call concurrently, including
+// with the same caller-supplied *rand.Rand.
+func (s *Superposition) RandomValue(src ...*rand.Rand) interface{} {
 	if len(s.values) == 0 {
 		return nil
 	}
-	return s.values[rand.Intn(len(s.values))]
+	r := defaultRand
+	if len(src) > 0 && src[0] != nil {
+		r = src[0]
+	}
+	randMu.Lock()
+	defer randMu.Unlock()
+	if len(s.weights) == len(s.values) {
+		return s.values[sampleWeightedIndex(s.weights, r)]
+	}
+	return s.values[r.Intn(len(s.values))]
+}
+
+// sampleWeightedIndex picks an index proportionally to weights by building
+// its cumulative distribution and binary-searching a uniform draw into it.
+func sampleWeightedIndex(weights []float64, r *rand.Rand) int {
+	cdf := make([]float64, len(weights))
+	sum := 0.0
+	for i, w := range weights {
+		sum += w
+		cdf[i] = sum
+	}
+	target := r.Float64() * sum
+	idx := sort.Search(len(cdf), func(i int) bool { return cdf[i] >= target })
+	if idx >= len(cdf) {
+		idx = len(cdf) - 1
+	}
+	return idx
+}
+
+// Collapse samples a single eigenstate, honoring weights if the
+// superposition has them, and returns a new superposition holding just that
+// value; s itself is left unchanged.
+func (s *Superposition) Collapse(src ...*rand.Rand) *Superposition {
+	return &Superposition{
+		values: []interface{}{s.RandomValue(src...)},
+		typ:    s.typ,
+	}
+}
+
+// Normalize rescales the superposition's weights in place to sum to 1. It is
+// a no-op on an unweighted superposition.
+func (s *Superposition) Normalize() {
+	if len(s.weights) == 0 {
+		return
+	}
+	sum := 0.0
+	for _, w := range s.weights {
+		sum += w
+	}
+	if sum == 0 {
+		return
+	}
+	for i := range s.weights {
+		s.weights[i] /= sum
+	}
+}
+
+// Weights returns the superposition's per-eigenstate amplitudes, or nil if it is unweighted.
+func (s *Superposition) Weights() []float64 {
+	return s.weights
 }
@@ -14,7 +14,20 @@ func TestAdd(t *testing.T) {
     if len(sp.Eigenstates()) != 1 {
         t.Errorf("Expected 1 eigenstate, got %d", len(sp.Eigenstates()))
     }
-    if sp.Eigenstates()[0] != 3 {
+    if sp.Eigenstates()[0] != 3.0 {
         t.Errorf("Expected result 3, got %v", sp.Eigenstates()[0])
     }
 }
+
+func TestEqualToStruct(t *testing.T) {
+    type point struct{ X, Y int }
+    set1 := Any(point{1, 2}, point{3, 4})
+    set2 := Any(point{3, 4}, point{5, 6})
+    intersection := EqualTo(set1, set2)
+    if len(intersection.Eigenstates()) != 1 {
+        t.Errorf("Expected 1 eigenstate, got %d", len(intersection.Eigenstates()))
+    }
+    if intersection.Eigenstates()[0] != (point{3, 4}) {
+        t.Errorf("Expected {3 4}, got %v", intersection.Eigenstates()[0])
+    }
+}
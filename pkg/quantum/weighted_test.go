@@ -0,0 +1,58 @@
+package quantum
+
+import (
+	"math/rand"
+	"sync"
+	"testing"
+)
+
+func TestAnyWeightedRandomValue(t *testing.T) {
+	sp := AnyWeighted([]interface{}{"a", "b"}, []float64{0, 1})
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 10; i++ {
+		if v := sp.RandomValue(r); v != "b" {
+			t.Fatalf("Expected always \"b\", got %v", v)
+		}
+	}
+}
+
+func TestCombineValuesPropagatesWeights(t *testing.T) {
+	a := AnyWeighted([]interface{}{1, 2}, []float64{1, 3})
+	b := AnyWeighted([]interface{}{10, 20}, []float64{1, 1})
+	sum := Add(a, b)
+	weights := sum.Weights()
+	if len(weights) != len(sum.Eigenstates()) {
+		t.Fatalf("Expected one weight per eigenstate, got %d weights for %d eigenstates", len(weights), len(sum.Eigenstates()))
+	}
+}
+
+func TestRandomValueConcurrentUseIsRaceFree(t *testing.T) {
+	sp := AnyWeighted([]interface{}{"a", "b"}, []float64{1, 1})
+	shared := rand.New(rand.NewSource(1))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			sp.RandomValue() // exercises the shared package-level defaultRand
+		}()
+		go func() {
+			defer wg.Done()
+			sp.RandomValue(shared) // exercises a caller-supplied *rand.Rand shared across goroutines
+		}()
+	}
+	wg.Wait()
+}
+
+func TestNormalize(t *testing.T) {
+	sp := AnyWeighted([]interface{}{1, 2}, []float64{1, 3})
+	sp.Normalize()
+	sum := 0.0
+	for _, w := range sp.Weights() {
+		sum += w
+	}
+	if sum < 0.999 || sum > 1.001 {
+		t.Errorf("Expected weights to sum to 1, got %v", sum)
+	}
+}